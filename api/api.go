@@ -7,16 +7,50 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"go.1password.io/eventsapibeat/api/metrics"
+	"go.1password.io/eventsapibeat/cursor"
 	"go.1password.io/eventsapibeat/utils"
 	"go.1password.io/eventsapibeat/version"
 )
 
 var DefaultUserAgent = "1Password Events API Beats / " + version.Version
 
+const (
+	DefaultMaxRetries = 4
+	DefaultMinWait    = 1 * time.Second
+	DefaultMaxWait    = 30 * time.Second
+)
+
+// ClientOptions tunes the retry/backoff behaviour of the underlying
+// retryablehttp.Client. MaxRetries, MinWait and MaxWait fall back to the
+// Default* constants when left unset; a zero Timeout leaves the HTTP
+// client's timeout unchanged.
+type ClientOptions struct {
+	MaxRetries int
+	MinWait    time.Duration
+	MaxWait    time.Duration
+	Timeout    time.Duration
+
+	// IsRetryableStatus, if set, is consulted in addition to
+	// retryablehttp's default retry policy (network errors, 429 and 5xx)
+	// so callers can retry on other statuses such as 409 without losing
+	// the default policy.
+	IsRetryableStatus func(statusCode int) bool
+
+	// RequestLogger, if set, is called before every attempt (including
+	// retries) with the attempt number starting at 0.
+	RequestLogger func(request *http.Request, attempt int)
+
+	// Transport, if set, replaces the retryable client's underlying
+	// http.RoundTripper, e.g. to inject a proxy or observability layer.
+	Transport http.RoundTripper
+}
+
 type Client struct {
 	httpClient *http.Client
 }
@@ -91,21 +125,124 @@ type ItemUsageClient struct {
 	IPAddress       string `json:"ip_address"`
 }
 
+type AuditEventResponse struct {
+	Cursor  string       `json:"cursor"`
+	HasMore bool         `json:"has_more"`
+	Items   []AuditEvent `json:"items"`
+}
+
+type AuditEvent struct {
+	UUID      string             `json:"uuid"`
+	Timestamp time.Time          `json:"timestamp"`
+	Action    string             `json:"action"`
+	Session   AuditEventSession  `json:"session"`
+	Actor     AuditEventActor    `json:"actor"`
+	Object    AuditEventObject   `json:"object"`
+	Location  AuditEventLocation `json:"location"`
+}
+
+type AuditEventSession struct {
+	UUID      string `json:"uuid"`
+	LoginTime string `json:"login_time"`
+	IP        string `json:"ip"`
+}
+
+type AuditEventActor struct {
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	ActorType string `json:"actor_type"`
+}
+
+type AuditEventObject struct {
+	UUID       string `json:"uuid"`
+	Name       string `json:"name"`
+	ObjectType string `json:"object_type"`
+}
+
+type AuditEventLocation struct {
+	Country   string  `json:"country"`
+	Region    string  `json:"region"`
+	City      string  `json:"city"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+const (
+	FeatureSignInAttempts = "signinattempts"
+	FeatureItemUsages     = "itemusages"
+	FeatureAuditEvents    = "auditevents"
+)
+
 type IntrospectResponse struct {
 	UUID     string    `json:"UUID"`
 	IssuedAt time.Time `json:"IssuedAt"`
 	Features []string  `json:"Features"`
 }
 
-func NewClient(logger retryablehttp.LeveledLogger, insecureSkipVerify bool) (*Client, error) {
+func (r *IntrospectResponse) HasFeature(feature string) bool {
+	for _, f := range r.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoints returns the Stream endpoints r's token actually advertises
+// support for, in the order beat inputs should be started. A beat
+// input loop should range over this instead of unconditionally
+// starting every endpoint.
+func (r *IntrospectResponse) Endpoints() []Endpoint {
+	var endpoints []Endpoint
+	for _, endpoint := range []Endpoint{EndpointSignInAttempts, EndpointItemUsages, EndpointAuditEvents} {
+		if r.HasFeature(string(endpoint)) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func NewClient(logger retryablehttp.LeveledLogger, insecureSkipVerify bool, options ClientOptions) (*Client, error) {
 	retryHTTPClient := retryablehttp.NewClient()
 	retryHTTPClient.Logger = logger
+
+	if options.Transport != nil {
+		retryHTTPClient.HTTPClient.Transport = options.Transport
+	}
 	if httpTransport, ok := retryHTTPClient.HTTPClient.Transport.(*http.Transport); insecureSkipVerify && ok {
 		httpTransport.TLSClientConfig = &tls.Config{
 			InsecureSkipVerify: insecureSkipVerify,
 		}
 	}
 
+	retryHTTPClient.RetryMax = DefaultMaxRetries
+	if options.MaxRetries > 0 {
+		retryHTTPClient.RetryMax = options.MaxRetries
+	}
+	retryHTTPClient.RetryWaitMin = DefaultMinWait
+	if options.MinWait > 0 {
+		retryHTTPClient.RetryWaitMin = options.MinWait
+	}
+	retryHTTPClient.RetryWaitMax = DefaultMaxWait
+	if options.MaxWait > 0 {
+		retryHTTPClient.RetryWaitMax = options.MaxWait
+	}
+	if options.Timeout > 0 {
+		retryHTTPClient.HTTPClient.Timeout = options.Timeout
+	}
+
+	retryHTTPClient.CheckRetry = retryCheckFor(options.IsRetryableStatus)
+	retryHTTPClient.Backoff = backoffWithRetryAfter
+	retryHTTPClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		if attempt > 0 {
+			metrics.RetryCount.Add(endpointLabel(request.URL.Path), tenantUUIDFromRequest(request), 1)
+		}
+		if options.RequestLogger != nil {
+			options.RequestLogger(request, attempt)
+		}
+	}
+
 	client := &Client{
 		httpClient: retryHTTPClient.StandardClient(),
 	}
@@ -113,6 +250,37 @@ func NewClient(logger retryablehttp.LeveledLogger, insecureSkipVerify bool) (*Cl
 	return client, nil
 }
 
+// retryCheckFor wraps retryablehttp's default retry policy (which
+// already retries on 429) with an optional caller-supplied status
+// predicate, so a partner tenant rate-limiting with some other
+// non-5xx status can still be retried.
+func retryCheckFor(isRetryableStatus func(statusCode int) bool) retryablehttp.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, response, err)
+		if retry || checkErr != nil {
+			return retry, checkErr
+		}
+		if isRetryableStatus != nil && response != nil && isRetryableStatus(response.StatusCode) {
+			return true, nil
+		}
+		return false, nil
+	}
+}
+
+// backoffWithRetryAfter honors a 429 or 503 response's Retry-After
+// header when present, falling back to a jittered linear backoff
+// otherwise so concurrent beats don't retry in lockstep.
+func backoffWithRetryAfter(min, max time.Duration, attempt int, response *http.Response) time.Duration {
+	if response != nil && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable) {
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil {
+			if wait := time.Duration(seconds) * time.Second; wait > 0 {
+				return wait
+			}
+		}
+	}
+	return retryablehttp.LinearJitterBackoff(min, max, attempt, response)
+}
+
 func (c *Client) HTTPClient() *http.Client {
 	return c.httpClient
 }
@@ -122,7 +290,7 @@ func (c *Client) Introspect(ctx context.Context, bearerToken string) (*Introspec
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new API request. %w", err)
 	}
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +314,7 @@ func (c *Client) SignInAttempts(ctx context.Context, bearerToken string, cursor
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new API request. %w", err)
 	}
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +333,36 @@ func (c *Client) SignInAttempts(ctx context.Context, bearerToken string, cursor
 	return &signInAttemptResponse, nil
 }
 
+func (c *Client) AuditEvents(ctx context.Context, bearerToken string, cursor string) (*AuditEventResponse, error) {
+	request, err := c.newAPIRequest(ctx, http.MethodPost, bearerToken, "/api/v1/auditevents", strings.NewReader(cursor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new API request. %w", err)
+	}
+	response, err := c.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code: %s", response.Status)
+	}
+
+	var auditEventResponse AuditEventResponse
+	err = json.NewDecoder(response.Body).Decode(&auditEventResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response. %w", err)
+	}
+
+	return &auditEventResponse, nil
+}
+
 func (c *Client) ItemUsages(ctx context.Context, bearerToken string, cursor string) (*ItemUsageResponse, error) {
 	request, err := c.newAPIRequest(ctx, http.MethodPost, bearerToken, "/api/v1/itemusages", strings.NewReader(cursor))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new API request. %w", err)
 	}
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +381,85 @@ func (c *Client) ItemUsages(ctx context.Context, bearerToken string, cursor stri
 	return &itemUsageResponse, nil
 }
 
+// Endpoint identifies one of the Events API streams Client.Stream
+// knows how to fetch.
+type Endpoint string
+
+const (
+	EndpointSignInAttempts Endpoint = FeatureSignInAttempts
+	EndpointItemUsages     Endpoint = FeatureItemUsages
+	EndpointAuditEvents    Endpoint = FeatureAuditEvents
+)
+
+// Stream repeatedly fetches batches for endpoint, starting from the
+// cursor last committed to store, invoking handler once per batch, and
+// only committing the new cursor once handler returns nil. It returns
+// once the Events API reports no more items or ctx is cancelled.
+//
+// Committing only after a successful handler call is the entire
+// at-least-once mechanism: a crash between fetch and commit leaves
+// store holding the previous cursor, so the next call to Stream simply
+// re-fetches (and re-hands-off) the same batch instead of losing it.
+func (c *Client) Stream(ctx context.Context, bearerToken string, endpoint Endpoint, store cursor.Store, handler func(items interface{}) error) error {
+	streamID := string(endpoint)
+
+	currentCursor, err := store.Load(streamID)
+	if err != nil {
+		return fmt.Errorf("failed to load cursor for %s. %w", streamID, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		items, nextCursor, hasMore, err := c.fetch(ctx, bearerToken, endpoint, currentCursor)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s. %w", streamID, err)
+		}
+
+		if err := handler(items); err != nil {
+			return fmt.Errorf("handler failed for %s. %w", streamID, err)
+		}
+
+		if err := store.Commit(streamID, nextCursor); err != nil {
+			return fmt.Errorf("failed to commit cursor for %s. %w", streamID, err)
+		}
+
+		currentCursor = nextCursor
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+func (c *Client) fetch(ctx context.Context, bearerToken string, endpoint Endpoint, fromCursor string) (items interface{}, nextCursor string, hasMore bool, err error) {
+	switch endpoint {
+	case EndpointSignInAttempts:
+		response, err := c.SignInAttempts(ctx, bearerToken, fromCursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return response.Items, response.Cursor, response.HasMore, nil
+	case EndpointItemUsages:
+		response, err := c.ItemUsages(ctx, bearerToken, fromCursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return response.Items, response.Cursor, response.HasMore, nil
+	case EndpointAuditEvents:
+		response, err := c.AuditEvents(ctx, bearerToken, fromCursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return response.Items, response.Cursor, response.HasMore, nil
+	default:
+		return nil, "", false, fmt.Errorf("unsupported stream endpoint: %s", endpoint)
+	}
+}
+
 func (c *Client) newAPIRequest(ctx context.Context, method string, bearerToken string, path string, body io.Reader) (*http.Request, error) {
 	jwt, err := utils.ParseJWTClaims(bearerToken)
 	if err != nil {
@@ -205,3 +476,49 @@ func (c *Client) newAPIRequest(ctx context.Context, method string, bearerToken s
 	request.Header.Add("User-Agent", DefaultUserAgent)
 	return request, nil
 }
+
+// do performs request and reports its latency, result and any
+// reported rate-limit quota to the metrics package, labeled by the
+// endpoint path and the tenant the bearer token belongs to.
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	endpoint := endpointLabel(request.URL.Path)
+	tenantUUID := tenantUUIDFromRequest(request)
+
+	start := time.Now()
+	response, err := c.httpClient.Do(request)
+	latency := time.Since(start)
+
+	status := "error"
+	if response != nil {
+		status = strconv.Itoa(response.StatusCode)
+		if remaining, parseErr := strconv.Atoi(response.Header.Get("X-RateLimit-Remaining")); parseErr == nil {
+			metrics.RateLimitRemaining.Set(endpoint, tenantUUID, remaining)
+		}
+	}
+	metrics.RequestLatency.Observe(endpoint, status, tenantUUID, latency)
+	metrics.RequestResult.Increment(endpoint, status, tenantUUID)
+
+	return response, err
+}
+
+// endpointLabel turns an API path such as "/api/v1/signinattempts" into
+// the short label ("signinattempts") used for metrics.
+func endpointLabel(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// tenantUUIDFromRequest re-derives the tenant UUID from the request's
+// bearer token for metrics labeling. It returns "" if the token is
+// missing or malformed rather than failing the request.
+func tenantUUIDFromRequest(request *http.Request) string {
+	bearerToken := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+	jwt, err := utils.ParseJWTClaims(bearerToken)
+	if err != nil {
+		return ""
+	}
+	tenantUUID, err := jwt.GetTenantUUID()
+	if err != nil {
+		return ""
+	}
+	return tenantUUID
+}
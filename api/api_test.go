@@ -0,0 +1,40 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntrospectResponseEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []string
+		want     []Endpoint
+	}{
+		{
+			name:     "no features",
+			features: nil,
+			want:     nil,
+		},
+		{
+			name:     "subset of features",
+			features: []string{"itemusages", "unknownfeature"},
+			want:     []Endpoint{EndpointItemUsages},
+		},
+		{
+			name:     "all features, order follows declared endpoint order not token order",
+			features: []string{"auditevents", "signinattempts", "itemusages"},
+			want:     []Endpoint{EndpointSignInAttempts, EndpointItemUsages, EndpointAuditEvents},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &IntrospectResponse{Features: tt.features}
+			got := response.Endpoints()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Endpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package metrics defines pluggable observers for api.Client HTTP
+// calls, modeled on the k8s.io/client-go pkg/client/metrics split: the
+// package exposes package-level interfaces that default to no-ops, and
+// callers opt into real instrumentation (e.g. Prometheus) by calling
+// Register.
+package metrics
+
+import "time"
+
+// LatencyMetric observes how long a request to endpoint took.
+type LatencyMetric interface {
+	Observe(endpoint, status, tenantUUID string, latency time.Duration)
+}
+
+// ResultMetric counts completed requests by their outcome.
+type ResultMetric interface {
+	Increment(endpoint, status, tenantUUID string)
+}
+
+// RetryMetric counts retry attempts issued for a request.
+type RetryMetric interface {
+	Add(endpoint, tenantUUID string, retries int)
+}
+
+// RateLimitMetric tracks the remaining rate-limit quota the API last
+// reported.
+type RateLimitMetric interface {
+	Set(endpoint, tenantUUID string, remaining int)
+}
+
+var (
+	RequestLatency     LatencyMetric   = noopLatency{}
+	RequestResult      ResultMetric    = noopResult{}
+	RetryCount         RetryMetric     = noopRetry{}
+	RateLimitRemaining RateLimitMetric = noopRateLimit{}
+)
+
+// RegisterOpts lets a caller install real observers. Any field left
+// nil keeps its current (by default no-op) observer.
+type RegisterOpts struct {
+	RequestLatency     LatencyMetric
+	RequestResult      ResultMetric
+	RetryCount         RetryMetric
+	RateLimitRemaining RateLimitMetric
+}
+
+// Register installs the observers in opts, leaving any unset field
+// untouched.
+func Register(opts RegisterOpts) {
+	if opts.RequestLatency != nil {
+		RequestLatency = opts.RequestLatency
+	}
+	if opts.RequestResult != nil {
+		RequestResult = opts.RequestResult
+	}
+	if opts.RetryCount != nil {
+		RetryCount = opts.RetryCount
+	}
+	if opts.RateLimitRemaining != nil {
+		RateLimitRemaining = opts.RateLimitRemaining
+	}
+}
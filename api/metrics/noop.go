@@ -0,0 +1,19 @@
+package metrics
+
+import "time"
+
+type noopLatency struct{}
+
+func (noopLatency) Observe(endpoint, status, tenantUUID string, latency time.Duration) {}
+
+type noopResult struct{}
+
+func (noopResult) Increment(endpoint, status, tenantUUID string) {}
+
+type noopRetry struct{}
+
+func (noopRetry) Add(endpoint, tenantUUID string, retries int) {}
+
+type noopRateLimit struct{}
+
+func (noopRateLimit) Set(endpoint, tenantUUID string, remaining int) {}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusObserver implements LatencyMetric, ResultMetric, RetryMetric
+// and RateLimitMetric on top of a prometheus.Registerer.
+type PrometheusObserver struct {
+	latency   *prometheus.HistogramVec
+	result    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	rateLimit *prometheus.GaugeVec
+}
+
+// NewPrometheusObserver registers its collectors with registerer and
+// returns an observer ready to pass to metrics.Register.
+func NewPrometheusObserver(registerer prometheus.Registerer) *PrometheusObserver {
+	labels := []string{"endpoint", "status", "tenant_uuid"}
+	observer := &PrometheusObserver{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "eventsapibeat",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of 1Password Events API requests.",
+		}, labels),
+		result: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "eventsapibeat",
+			Name:      "requests_total",
+			Help:      "Count of 1Password Events API requests by result.",
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "eventsapibeat",
+			Name:      "request_retries_total",
+			Help:      "Count of 1Password Events API retry attempts.",
+		}, []string{"endpoint", "tenant_uuid"}),
+		rateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "eventsapibeat",
+			Name:      "rate_limit_remaining",
+			Help:      "Remaining rate-limit quota reported by the last 1Password Events API response.",
+		}, []string{"endpoint", "tenant_uuid"}),
+	}
+	registerer.MustRegister(observer.latency, observer.result, observer.retries, observer.rateLimit)
+	return observer
+}
+
+func (o *PrometheusObserver) Observe(endpoint, status, tenantUUID string, latency time.Duration) {
+	o.latency.WithLabelValues(endpoint, status, tenantUUID).Observe(latency.Seconds())
+}
+
+func (o *PrometheusObserver) Increment(endpoint, status, tenantUUID string) {
+	o.result.WithLabelValues(endpoint, status, tenantUUID).Inc()
+}
+
+func (o *PrometheusObserver) Add(endpoint, tenantUUID string, retries int) {
+	o.retries.WithLabelValues(endpoint, tenantUUID).Add(float64(retries))
+}
+
+func (o *PrometheusObserver) Set(endpoint, tenantUUID string, remaining int) {
+	o.rateLimit.WithLabelValues(endpoint, tenantUUID).Set(float64(remaining))
+}
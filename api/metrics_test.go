@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpointLabel(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/signinattempts", want: "signinattempts"},
+		{path: "/api/v1/itemusages", want: "itemusages"},
+		{path: "/api/v1/auditevents", want: "auditevents"},
+		{path: "/api/auth/introspect", want: "introspect"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := endpointLabel(tt.path); got != tt.want {
+				t.Fatalf("endpointLabel(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantUUIDFromRequestMalformedToken(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	request.Header.Set("Authorization", "Bearer not-a-jwt")
+
+	if got := tenantUUIDFromRequest(request); got != "" {
+		t.Fatalf("tenantUUIDFromRequest() = %q, want empty string for a malformed token", got)
+	}
+}
+
+func TestClientDoPerformsTheRequest(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client()}
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/signinattempts", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	response, err := client.do(request)
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	defer response.Body.Close()
+
+	if gotPath != "/api/v1/signinattempts" {
+		t.Fatalf("server saw path %q, want the request to have actually reached it", gotPath)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("do() status = %d, want %d", response.StatusCode, http.StatusOK)
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryCheckFor(t *testing.T) {
+	tests := []struct {
+		name              string
+		isRetryableStatus func(statusCode int) bool
+		statusCode        int
+		want              bool
+	}{
+		{
+			name:              "429 retried without a predicate",
+			isRetryableStatus: nil,
+			statusCode:        http.StatusTooManyRequests,
+			want:              true,
+		},
+		{
+			name:              "200 not retried without a predicate",
+			isRetryableStatus: nil,
+			statusCode:        http.StatusOK,
+			want:              false,
+		},
+		{
+			name:              "status only retried when predicate says so",
+			isRetryableStatus: func(statusCode int) bool { return statusCode == http.StatusConflict },
+			statusCode:        http.StatusConflict,
+			want:              true,
+		},
+		{
+			name:              "predicate declining a status is not overridden",
+			isRetryableStatus: func(statusCode int) bool { return false },
+			statusCode:        http.StatusOK,
+			want:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRetry := retryCheckFor(tt.isRetryableStatus)
+			response := &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(strings.NewReader(""))}
+			retry, err := checkRetry(context.Background(), response, nil)
+			if err != nil {
+				t.Fatalf("checkRetry() error = %v", err)
+			}
+			if retry != tt.want {
+				t.Fatalf("checkRetry() = %v, want %v", retry, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffWithRetryAfter(t *testing.T) {
+	min, max := 1*time.Second, 30*time.Second
+
+	tests := []struct {
+		name       string
+		statusCode int
+		retryAfter string
+		want       time.Duration
+	}{
+		{
+			name:       "429 with Retry-After honors the header",
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: "5",
+			want:       5 * time.Second,
+		},
+		{
+			name:       "503 with Retry-After honors the header",
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "7",
+			want:       7 * time.Second,
+		},
+		{
+			name:       "429 without Retry-After falls back to jittered backoff",
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: "",
+		},
+		{
+			name:       "500 ignores Retry-After and falls back to jittered backoff",
+			statusCode: http.StatusInternalServerError,
+			retryAfter: "5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.retryAfter != "" {
+				header.Set("Retry-After", tt.retryAfter)
+			}
+			response := &http.Response{StatusCode: tt.statusCode, Header: header}
+
+			got := backoffWithRetryAfter(min, max, 0, response)
+			if tt.want != 0 {
+				if got != tt.want {
+					t.Fatalf("backoffWithRetryAfter() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if got < min || got > max {
+				t.Fatalf("backoffWithRetryAfter() = %v, want a value within [%v, %v]", got, min, max)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package cursor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is a file-backed Store, writing each stream's cursor to its
+// own file under dir.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cursor store directory. %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Load(streamID string) (string, error) {
+	data, err := os.ReadFile(s.path(streamID))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read cursor for %s. %w", streamID, err)
+	}
+	return string(data), nil
+}
+
+// Commit writes the cursor via a temp file + rename so a crash
+// mid-write can never leave a torn cursor behind.
+func (s *FileStore) Commit(streamID string, cursor string) error {
+	tmp, err := os.CreateTemp(s.dir, streamID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp cursor file for %s. %w", streamID, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(cursor); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cursor for %s. %w", streamID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp cursor file for %s. %w", streamID, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(streamID)); err != nil {
+		return fmt.Errorf("failed to commit cursor for %s. %w", streamID, err)
+	}
+	return nil
+}
+
+func (s *FileStore) Reset(streamID string) error {
+	if err := os.Remove(s.path(streamID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to reset cursor for %s. %w", streamID, err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(streamID string) string {
+	return filepath.Join(s.dir, streamID+".cursor")
+}
@@ -0,0 +1,106 @@
+package cursor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreLoadMissing(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	got, err := store.Load("signinattempts")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != "" {
+		t.Fatalf("Load() = %q, want empty string", got)
+	}
+}
+
+func TestFileStoreCommitAndLoad(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if err := store.Commit("itemusages", "cursor-1"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	got, err := store.Load("itemusages")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != "cursor-1" {
+		t.Fatalf("Load() = %q, want %q", got, "cursor-1")
+	}
+
+	if err := store.Commit("itemusages", "cursor-2"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	got, err = store.Load("itemusages")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != "cursor-2" {
+		t.Fatalf("Load() after second commit = %q, want %q", got, "cursor-2")
+	}
+}
+
+func TestFileStoreCommitLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := store.Commit("auditevents", "cursor-1"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "auditevents.cursor" {
+		t.Fatalf("ReadDir() = %v, want exactly [auditevents.cursor]", entries)
+	}
+}
+
+func TestFileStoreResetRemovesCursor(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := store.Commit("signinattempts", "cursor-1"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if err := store.Reset("signinattempts"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "signinattempts.cursor")); !os.IsNotExist(err) {
+		t.Fatalf("cursor file still exists after Reset(), stat err = %v", err)
+	}
+
+	got, err := store.Load("signinattempts")
+	if err != nil {
+		t.Fatalf("Load() after Reset() error = %v", err)
+	}
+	if got != "" {
+		t.Fatalf("Load() after Reset() = %q, want empty string", got)
+	}
+}
+
+func TestFileStoreResetMissingIsNotAnError(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := store.Reset("never-committed"); err != nil {
+		t.Fatalf("Reset() on missing cursor error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cursor
+
+import "sync"
+
+// Store persists the last-committed cursor for a stream (e.g.
+// "signinattempts", "itemusages") so a beat can resume where it left
+// off across restarts instead of replaying the whole event history or
+// dropping events.
+type Store interface {
+	Load(streamID string) (string, error)
+	Commit(streamID string, cursor string) error
+	Reset(streamID string) error
+}
+
+// MemoryStore is an in-memory Store. It is useful for tests and for
+// beats that are fine re-processing from the start of the retention
+// window after every restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cursors: make(map[string]string)}
+}
+
+func (s *MemoryStore) Load(streamID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[streamID], nil
+}
+
+func (s *MemoryStore) Commit(streamID string, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[streamID] = cursor
+	return nil
+}
+
+func (s *MemoryStore) Reset(streamID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cursors, streamID)
+	return nil
+}